@@ -0,0 +1,43 @@
+package xlsx
+
+// options collects the settings applied by Option values passed to
+// OpenReaderWithOptions.
+type options struct {
+	rowLimit           int
+	sheets             map[string]bool
+	sharedStringsLimit int
+}
+
+// Option configures OpenReaderWithOptions.
+type Option func(*options)
+
+// WithRowLimit caps how many data rows a sheet's RowIter yields before
+// Next() reports false, regardless of how many rows the sheet actually
+// has. The limit is enforced inside the streaming iterator, not by
+// buffering, and applies independently to each sheet.
+func WithRowLimit(n int) Option {
+	return func(o *options) {
+		o.rowLimit = n
+	}
+}
+
+// WithSheets restricts extraction to the named sheets, skipping the rest
+// entirely. Sheet names not present in the workbook are silently ignored.
+func WithSheets(names ...string) Option {
+	return func(o *options) {
+		allow := make(map[string]bool, len(names))
+		for _, name := range names {
+			allow[name] = true
+		}
+		o.sheets = allow
+	}
+}
+
+// WithSharedStringsLimit bounds how many entries are parsed out of
+// xl/sharedStrings.xml, which is useful when reading untrusted files that
+// might otherwise claim an unreasonable uniqueCount.
+func WithSharedStringsLimit(n int) Option {
+	return func(o *options) {
+		o.sharedStringsLimit = n
+	}
+}
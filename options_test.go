@@ -0,0 +1,108 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseSharedLimit(t *testing.T) {
+	const sharedStringsText = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="4" uniqueCount="4"><si><t>A</t></si><si><t>B</t></si><si><t>C</t></si><si><t>D</t></si></sst>`
+
+	ss, err := parseSharedLimit(strings.NewReader(sharedStringsText), 2)
+	if err != nil {
+		t.Fatalf("Unexpected err: %q", err)
+	}
+	if len(ss) != 2 || ss[0] != "A" || ss[1] != "B" {
+		t.Fatalf("Unexpected limited shared strings: %+v", ss)
+	}
+}
+
+const multiRowSheetXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1"><v>1</v></c></row><row r="2"><c r="A2"><v>2</v></c></row><row r="3"><c r="A3"><v>3</v></c></row></sheetData></worksheet>`
+
+func buildMultiRowXLSX(t *testing.T) *bytes.Reader {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+
+	files := map[string]string{
+		"[Content_Types].xml":        nonCanonicalContentTypes,
+		"xl/workbook.xml":            nonCanonicalWorkbook,
+		"xl/_rels/workbook.xml.rels": nonCanonicalRels,
+		"xl/worksheet_foo.xml":       multiRowSheetXML,
+		"xl/worksheet_bar.xml":       minimalSheetXML,
+	}
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestWithRowLimit(t *testing.T) {
+	r := buildMultiRowXLSX(t)
+
+	file, err := OpenReaderWithOptions(r, int64(r.Len()), WithRowLimit(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	it, err := file.Sheets()[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+
+	var rows [][]string
+	for it.Next() {
+		rows = append(rows, it.Row())
+	}
+	if err := it.Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 2 || rows[0][0] != "1" || rows[1][0] != "2" {
+		t.Fatalf("Unexpected rows with WithRowLimit(2): %+v", rows)
+	}
+}
+
+func TestOpenBinaryAndWithSheets(t *testing.T) {
+	r := buildNonCanonicalXLSX(t)
+	b := make([]byte, r.Len())
+	if _, err := r.Read(b); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := OpenReaderWithOptions(bytes.NewReader(b), int64(len(b)), WithSheets("Sheet2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	sheets := file.Sheets()
+	if len(sheets) != 1 || sheets[0].Name != "Sheet2" {
+		t.Fatalf("Unexpected filtered sheets: %+v", sheets)
+	}
+
+	file2, err := OpenBinary(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file2.Close()
+
+	if len(file2.Sheets()) != 2 {
+		t.Fatalf("Unexpected sheet count from OpenBinary: %d", len(file2.Sheets()))
+	}
+}
@@ -0,0 +1,84 @@
+package xlsx
+
+import (
+	"context"
+	"fmt"
+)
+
+// sheetResult is an indexed SheetsParallel outcome: keeping the sheet's
+// original position lets us report errors deterministically even though
+// workers finish out of order.
+type sheetResult struct {
+	idx int
+	err error
+}
+
+// SheetsParallel opens and streams every sheet through fn concurrently
+// using workers goroutines. It's meant for workbooks with many sheets,
+// where sheet.Open() re-parsing XML on demand makes sequential reading a
+// bottleneck.
+//
+// archive/zip.File.Open() is safe for concurrent use across distinct
+// *zip.File values, so each worker can open its own sheet's zip entry in
+// parallel; the only shared state, xlsx.sharedStrings and the lazily
+// built style table, is either read-only or guarded by sync.Once.
+//
+// SheetsParallel returns the first error encountered, in sheet order, or
+// nil if every sheet was processed successfully. A non-positive workers
+// is treated as 1.
+func (xlsx *XLSX) SheetsParallel(ctx context.Context, workers int, fn func(*Sheet, RowIter) error) error {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	sheets := xlsx.sheets
+	jobs := make(chan int)
+	results := make(chan sheetResult, len(sheets))
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			for idx := range jobs {
+				results <- sheetResult{idx: idx, err: processSheet(ctx, sheets[idx], fn)}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for i := range sheets {
+			jobs <- i
+		}
+	}()
+
+	out := make([]error, len(sheets))
+	for range sheets {
+		res := <-results
+		out[res.idx] = res.err
+	}
+
+	for _, err := range out {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func processSheet(ctx context.Context, sheet *Sheet, fn func(*Sheet, RowIter) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r, err := sheet.Open()
+	if err != nil {
+		return fmt.Errorf("sheet %s: %w", sheet.Name, err)
+	}
+	defer r.Close()
+
+	if err := fn(sheet, r); err != nil {
+		return fmt.Errorf("sheet %s: %w", sheet.Name, err)
+	}
+
+	return nil
+}
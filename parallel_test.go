@@ -0,0 +1,160 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSheetsParallelVisitsEveryKnownSheet(t *testing.T) {
+	r := buildNonCanonicalXLSX(t)
+
+	file, err := OpenReader(r, int64(r.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+
+	err = file.SheetsParallel(context.Background(), 2, func(sheet *Sheet, rows RowIter) error {
+		mu.Lock()
+		seen[sheet.Name] = true
+		mu.Unlock()
+		for rows.Next() {
+		}
+		return rows.Error()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !seen["Sheet1"] || !seen["Sheet2"] {
+		t.Fatalf("Unexpected sheets visited: %+v", seen)
+	}
+}
+
+func TestSheetsParallelPropagatesError(t *testing.T) {
+	r := buildNonCanonicalXLSX(t)
+
+	file, err := OpenReader(r, int64(r.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	boom := errors.New("boom")
+	err = file.SheetsParallel(context.Background(), 2, func(sheet *Sheet, rows RowIter) error {
+		if sheet.Name == "Sheet1" {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("Unexpected err: %v, want wrapping %v", err, boom)
+	}
+}
+
+// buildParallelBenchXLSX builds a self-contained in-memory workbook with
+// sheetCount sheets of rowsPerSheet rows each, so BenchmarkSheetsSequential
+// and BenchmarkSheetsParallel can actually run (and show a speedup)
+// without depending on an external fixture file.
+func buildParallelBenchXLSX(b *testing.B, sheetCount, rowsPerSheet int) *bytes.Reader {
+	b.Helper()
+
+	var rows strings.Builder
+	for r := 1; r <= rowsPerSheet; r++ {
+		fmt.Fprintf(&rows, `<row r="%d"><c r="A%d"><v>%d</v></c><c r="B%d"><v>%d</v></c></row>`, r, r, r, r, r*2)
+	}
+	sheetXML := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + rows.String() + `</sheetData></worksheet>`
+
+	var contentTypes, sheetsXML, rels strings.Builder
+	contentTypes.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?><Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	rels.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?><Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	files := map[string]string{}
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+		fmt.Fprintf(&sheetsXML, `<sheet name="Sheet%d" sheetId="%d" r:id="rId%d"/>`, i, i, i)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+		files[fmt.Sprintf("xl/worksheets/sheet%d.xml", i)] = sheetXML
+	}
+	contentTypes.WriteString(`</Types>`)
+	rels.WriteString(`</Relationships>`)
+
+	workbook := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>` + sheetsXML.String() + `</sheets></workbook>`
+
+	files["[Content_Types].xml"] = contentTypes.String()
+	files["xl/workbook.xml"] = workbook
+	files["xl/_rels/workbook.xml.rels"] = rels.String()
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			b.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		b.Fatal(err)
+	}
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func BenchmarkSheetsSequential(b *testing.B) {
+	r := buildParallelBenchXLSX(b, 8, 2000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		file, err := OpenReader(r, int64(r.Len()))
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, sheet := range file.Sheets() {
+			rows, err := sheet.Open()
+			if err != nil {
+				b.Fatal(err)
+			}
+			for rows.Next() {
+				_ = rows.Row()
+			}
+			rows.Close()
+		}
+		file.Close()
+	}
+}
+
+func BenchmarkSheetsParallel(b *testing.B) {
+	r := buildParallelBenchXLSX(b, 8, 2000)
+
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		file, err := OpenReader(r, int64(r.Len()))
+		if err != nil {
+			b.Fatal(err)
+		}
+		err = file.SheetsParallel(ctx, 4, func(_ *Sheet, rows RowIter) error {
+			for rows.Next() {
+				_ = rows.Row()
+			}
+			return rows.Error()
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		file.Close()
+	}
+}
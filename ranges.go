@@ -0,0 +1,235 @@
+package xlsx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Range is a decoded A1-style cell range, as used by <definedName> bodies
+// and formulas: columns and rows are 0-based, and the Abs flags record
+// whether the corresponding part carried a `$` absolute marker.
+type Range struct {
+	Sheet string
+
+	StartCol, StartRow int
+	EndCol, EndRow     int
+
+	StartColAbs, StartRowAbs bool
+	EndColAbs, EndRowAbs     bool
+}
+
+// DefinedName is a workbook-level or sheet-level named range, as declared
+// by xl/workbook.xml's <definedNames><definedName name="..."> entries.
+// Scope is empty for a workbook-global name, or the owning sheet's name
+// for one scoped with localSheetId.
+type DefinedName struct {
+	Name  string
+	Scope string
+	Ref   Range
+}
+
+// resolveDefinedNames turns the raw <definedName> elements collected while
+// parsing xl/workbook.xml into DefinedName values, resolving localSheetId
+// (a 0-based index into the declared <sheets>) to the sheet's name.
+func resolveDefinedNames(raw []rawDefinedName, sheets []sheetEntry) []DefinedName {
+	names := make([]DefinedName, 0, len(raw))
+
+	for _, rn := range raw {
+		dn := DefinedName{Name: rn.name}
+
+		if rn.localSheetID != "" {
+			if idx, err := strconv.Atoi(rn.localSheetID); err == nil && idx >= 0 && idx < len(sheets) {
+				dn.Scope = sheets[idx].name
+			}
+		}
+
+		ref, err := ParseRange(rn.ref)
+		if err == nil {
+			dn.Ref = ref
+		}
+
+		names = append(names, dn)
+	}
+
+	return names
+}
+
+// ParseRange decodes an A1-style range, optionally prefixed with a sheet
+// name ("Sheet1!A1:B2" or "'My Sheet'!A1"), and supporting absolute `$`
+// column/row markers. A single cell (no ":") is returned as a
+// single-cell range.
+func ParseRange(ref string) (Range, error) {
+	var r Range
+
+	sheet, cells := splitSheetRef(ref)
+	r.Sheet = sheet
+
+	parts := strings.SplitN(cells, ":", 2)
+
+	start, err := parseCellRef(parts[0])
+	if err != nil {
+		return r, fmt.Errorf("parsing range %q: %w", ref, err)
+	}
+	r.StartCol, r.StartRow = start.col, start.row
+	r.StartColAbs, r.StartRowAbs = start.colAbs, start.rowAbs
+
+	end := start
+	if len(parts) == 2 {
+		end, err = parseCellRef(parts[1])
+		if err != nil {
+			return r, fmt.Errorf("parsing range %q: %w", ref, err)
+		}
+	}
+	r.EndCol, r.EndRow = end.col, end.row
+	r.EndColAbs, r.EndRowAbs = end.colAbs, end.rowAbs
+
+	return r, nil
+}
+
+// splitSheetRef peels off an optional leading "Sheet!" or "'Sheet Name'!"
+// from a defined-name reference.
+func splitSheetRef(ref string) (sheet, cells string) {
+	if strings.HasPrefix(ref, "'") {
+		if end := strings.Index(ref[1:], "'"); end >= 0 {
+			end += 1
+			sheet = ref[1:end]
+			rest := strings.TrimPrefix(ref[end+1:], "!")
+			return sheet, rest
+		}
+	}
+
+	if i := strings.IndexByte(ref, '!'); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+
+	return "", ref
+}
+
+type cellRef struct {
+	col, row       int
+	colAbs, rowAbs bool
+}
+
+// parseCellRef decodes a single A1-style cell reference such as "A1",
+// "$B$2" or "AA10".
+func parseCellRef(s string) (cellRef, error) {
+	var c cellRef
+
+	i := 0
+	if i < len(s) && s[i] == '$' {
+		c.colAbs = true
+		i++
+	}
+
+	colStart := i
+	for i < len(s) && s[i] >= 'A' && s[i] <= 'Z' {
+		i++
+	}
+	if i == colStart {
+		return c, fmt.Errorf("invalid cell reference %q", s)
+	}
+	c.col = columnToIndex(s[colStart:i])
+
+	if i < len(s) && s[i] == '$' {
+		c.rowAbs = true
+		i++
+	}
+
+	rowStart := i
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == rowStart || i != len(s) {
+		return c, fmt.Errorf("invalid cell reference %q", s)
+	}
+
+	row, err := strconv.Atoi(s[rowStart:i])
+	if err != nil {
+		return c, err
+	}
+	c.row = row - 1
+
+	return c, nil
+}
+
+// columnToIndex converts an Excel column letter (e.g. "A", "Z", "AA") into
+// a 0-based index.
+func columnToIndex(letters string) int {
+	idx := 0
+	for _, r := range letters {
+		idx = idx*26 + int(r-'A'+1)
+	}
+	return idx - 1
+}
+
+// RowsInRange returns a RowIter restricted to the rows and columns covered
+// by ref, so a caller who knows a named range can iterate just its cells
+// instead of the whole sheet.
+func (s *Sheet) RowsInRange(ref Range) (RowIter, error) {
+	it, err := s.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return &rangeRowIter{RowIter: it, ref: ref}, nil
+}
+
+// rangeRowIter wraps a sheet's RowIter, skipping rows before ref.StartRow,
+// stopping after ref.EndRow, and trimming each row to [StartCol, EndCol].
+// Rows are matched by RowNumber (the worksheet's own <row r="..."> index)
+// rather than by counting Next calls, since producers commonly omit <row>
+// elements for blank rows.
+type rangeRowIter struct {
+	RowIter
+	ref Range
+}
+
+// Next advances to the next row inside the range, skipping rows outside
+// it and stopping once the range has been fully consumed.
+func (it *rangeRowIter) Next() bool {
+	for it.RowIter.Next() {
+		n := it.RowIter.RowNumber()
+		if n < it.ref.StartRow {
+			continue
+		}
+		if n > it.ref.EndRow {
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// Row returns the current row's cells, trimmed to [StartCol, EndCol].
+func (it *rangeRowIter) Row() []string {
+	row := it.RowIter.Row()
+
+	start := it.ref.StartCol
+	end := it.ref.EndCol + 1
+	if start > len(row) {
+		return nil
+	}
+	if end > len(row) {
+		end = len(row)
+	}
+
+	return row[start:end]
+}
+
+// Cells returns the current row's classified cells, trimmed the same way
+// as Row.
+func (it *rangeRowIter) Cells() []Cell {
+	cells := it.RowIter.Cells()
+
+	start := it.ref.StartCol
+	end := it.ref.EndCol + 1
+	if start > len(cells) {
+		return nil
+	}
+	if end > len(cells) {
+		end = len(cells)
+	}
+
+	return cells[start:end]
+}
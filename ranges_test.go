@@ -0,0 +1,299 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func TestParseRangeSimple(t *testing.T) {
+	r, err := ParseRange("A1:B10")
+	if err != nil {
+		t.Fatalf("Unexpected err: %q", err)
+	}
+	if r.StartCol != 0 || r.StartRow != 0 || r.EndCol != 1 || r.EndRow != 9 {
+		t.Fatalf("Unexpected range: %+v", r)
+	}
+}
+
+func TestParseRangeAbsolute(t *testing.T) {
+	r, err := ParseRange("$B$2:$C$4")
+	if err != nil {
+		t.Fatalf("Unexpected err: %q", err)
+	}
+	if !r.StartColAbs || !r.StartRowAbs || !r.EndColAbs || !r.EndRowAbs {
+		t.Fatalf("Unexpected absolute flags: %+v", r)
+	}
+	if r.StartCol != 1 || r.StartRow != 1 {
+		t.Fatalf("Unexpected start: %+v", r)
+	}
+}
+
+func TestParseRangeCrossSheet(t *testing.T) {
+	r, err := ParseRange("'My Sheet'!A1:B2")
+	if err != nil {
+		t.Fatalf("Unexpected err: %q", err)
+	}
+	if r.Sheet != "My Sheet" {
+		t.Fatalf("Unexpected sheet: %q", r.Sheet)
+	}
+
+	r, err = ParseRange("Sheet1!AA1")
+	if err != nil {
+		t.Fatalf("Unexpected err: %q", err)
+	}
+	if r.Sheet != "Sheet1" || r.StartCol != columnToIndex("AA") {
+		t.Fatalf("Unexpected range: %+v", r)
+	}
+}
+
+const definedNamesWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/><sheet name="Sheet2" sheetId="2" r:id="rId2"/></sheets><definedNames><definedName name="TotalRange">Sheet1!$A$1:$D$10</definedName><definedName name="Local" localSheetId="1">Sheet2!A1:B2</definedName></definedNames></workbook>`
+
+func TestDefinedNames(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+
+	files := map[string]string{
+		"[Content_Types].xml":        nonCanonicalContentTypes,
+		"xl/workbook.xml":            definedNamesWorkbook,
+		"xl/_rels/workbook.xml.rels": nonCanonicalRels,
+		"xl/worksheet_foo.xml":       minimalSheetXML,
+		"xl/worksheet_bar.xml":       minimalSheetXML,
+	}
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := bytes.NewReader(buf.Bytes())
+	file, err := OpenReader(r, int64(r.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	names := file.DefinedNames()
+	if len(names) != 2 {
+		t.Fatalf("Unexpected defined names: %+v", names)
+	}
+
+	if names[0].Name != "TotalRange" || names[0].Scope != "" || names[0].Ref.Sheet != "Sheet1" {
+		t.Fatalf("Unexpected first name: %+v", names[0])
+	}
+	if names[1].Name != "Local" || names[1].Scope != "Sheet2" || names[1].Ref.Sheet != "Sheet2" {
+		t.Fatalf("Unexpected second name: %+v", names[1])
+	}
+}
+
+// gridSheetXML is a 4x4 grid ("A1".."D4", values "r<row>c<col>") used to
+// exercise RowsInRange's row-skip and column-trim behavior.
+const gridSheetXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+	`<row r="1"><c r="A1"><v>r1c1</v></c><c r="B1"><v>r1c2</v></c><c r="C1"><v>r1c3</v></c><c r="D1"><v>r1c4</v></c></row>` +
+	`<row r="2"><c r="A2"><v>r2c1</v></c><c r="B2"><v>r2c2</v></c><c r="C2"><v>r2c3</v></c><c r="D2"><v>r2c4</v></c></row>` +
+	`<row r="3"><c r="A3"><v>r3c1</v></c><c r="B3"><v>r3c2</v></c><c r="C3"><v>r3c3</v></c><c r="D3"><v>r3c4</v></c></row>` +
+	`<row r="4"><c r="A4"><v>r4c1</v></c><c r="B4"><v>r4c2</v></c><c r="C4"><v>r4c3</v></c><c r="D4"><v>r4c4</v></c></row>` +
+	`</sheetData></worksheet>`
+
+func buildGridXLSX(t *testing.T) *bytes.Reader {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+
+	files := map[string]string{
+		"[Content_Types].xml":        nonCanonicalContentTypes,
+		"xl/workbook.xml":            nonCanonicalWorkbook,
+		"xl/_rels/workbook.xml.rels": nonCanonicalRels,
+		"xl/worksheet_foo.xml":       gridSheetXML,
+		"xl/worksheet_bar.xml":       minimalSheetXML,
+	}
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestRowsInRangeSkipsRowsAndTrimsColumns(t *testing.T) {
+	r := buildGridXLSX(t)
+	file, err := OpenReader(r, int64(r.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	// B2:C3 should skip row 1 and row 4, and trim each kept row to
+	// columns B and C.
+	ref, err := ParseRange("B2:C3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it, err := file.Sheets()[0].RowsInRange(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+
+	var rows [][]string
+	for it.Next() {
+		rows = append(rows, it.Row())
+	}
+	if err := it.Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{
+		{"r2c2", "r2c3"},
+		{"r3c2", "r3c3"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("Unexpected rows: %+v", rows)
+	}
+	for i := range want {
+		if len(rows[i]) != len(want[i]) || rows[i][0] != want[i][0] || rows[i][1] != want[i][1] {
+			t.Fatalf("Unexpected row %d: %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestRowsInRangeCells(t *testing.T) {
+	r := buildGridXLSX(t)
+	file, err := OpenReader(r, int64(r.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	ref, err := ParseRange("B2:C3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it, err := file.Sheets()[0].RowsInRange(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+
+	if !it.Next() {
+		t.Fatalf("Expected a first row, err: %v", it.Error())
+	}
+	cells := it.Cells()
+	if len(cells) != 2 || cells[0].Raw != "r2c2" || cells[1].Raw != "r2c3" {
+		t.Fatalf("Unexpected cells for first row: %+v", cells)
+	}
+}
+
+// sparseSheetXML has real data only in rows 1 and 5 (rows 2-4 are blank
+// and therefore omitted, as real producers do) and leaves gaps in its
+// columns (B and D only), to make sure row/column alignment is driven by
+// each element's own r="..." reference rather than by position.
+const sparseSheetXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+	`<row r="1"><c r="B1"><v>b1</v></c><c r="D1"><v>d1</v></c></row>` +
+	`<row r="5"><c r="A5"><v>a5</v></c><c r="B5"><v>b5</v></c></row>` +
+	`</sheetData></worksheet>`
+
+func buildSparseXLSX(t *testing.T) *bytes.Reader {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+
+	files := map[string]string{
+		"[Content_Types].xml":        nonCanonicalContentTypes,
+		"xl/workbook.xml":            nonCanonicalWorkbook,
+		"xl/_rels/workbook.xml.rels": nonCanonicalRels,
+		"xl/worksheet_foo.xml":       sparseSheetXML,
+		"xl/worksheet_bar.xml":       minimalSheetXML,
+	}
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestSheetOpenAlignsSparseColumns(t *testing.T) {
+	r := buildSparseXLSX(t)
+	file, err := OpenReader(r, int64(r.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	it, err := file.Sheets()[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+
+	if !it.Next() {
+		t.Fatalf("Expected a row, err: %v", it.Error())
+	}
+	row := it.Row()
+	if len(row) != 4 || row[1] != "b1" || row[3] != "d1" {
+		t.Fatalf("Unexpected sparse-column row: %+v", row)
+	}
+}
+
+func TestRowsInRangeSkipsBlankRowsByRealRowNumber(t *testing.T) {
+	r := buildSparseXLSX(t)
+	file, err := OpenReader(r, int64(r.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	// Rows 2-4 don't exist in the XML at all; RowsInRange must still
+	// land on row 5 rather than mistaking the second <row> it reads for
+	// row 2.
+	ref, err := ParseRange("A5:B5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it, err := file.Sheets()[0].RowsInRange(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+
+	var rows [][]string
+	for it.Next() {
+		rows = append(rows, it.Row())
+	}
+	if err := it.Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 1 || rows[0][0] != "a5" || rows[0][1] != "b5" {
+		t.Fatalf("Unexpected rows: %+v", rows)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 
 	xml "github.com/dgrr/quickxml"
 )
@@ -18,8 +20,50 @@ type XLSX struct {
 	zr            *zip.Reader
 	sheets        []*Sheet
 	closer        io.Closer
+
+	date1904 bool
+
+	stylesOnce sync.Once
+	stylesFile *zip.File
+	styles     *Styles
+	stylesErr  error
+
+	sharedRunsOnce    sync.Once
+	sharedStringsFile *zip.File
+	sharedRuns        [][]Run
+	sharedRunsErr     error
+
+	rowLimit int
+
+	definedNames []DefinedName
 }
 
+// Element/attribute names used to join xl/workbook.xml against
+// xl/_rels/workbook.xml.rels so sheets are resolved by relationship id
+// rather than by guessing from the part's filename.
+var (
+	rIDString          = []byte("r:id")
+	relationshipString = []byte("Relationship")
+	idString           = []byte("Id")
+	targetString       = []byte("Target")
+)
+
+// Element/attribute names used while parsing rich-text runs inside
+// xl/sharedStrings.xml <si> entries.
+var (
+	siString    = []byte("si")
+	rString     = []byte("r")
+	rPrString   = []byte("rPr")
+	rFontString = []byte("rFont")
+)
+
+// Element/attribute names used while parsing <definedNames> in
+// xl/workbook.xml.
+var (
+	definedNameString  = []byte("definedName")
+	localSheetIDString = []byte("localSheetId")
+)
+
 // sheetData
 //   row: r="1"
 //     c: r="A1" t="inlineStr"|"n" s="1"
@@ -31,7 +75,17 @@ type XLSX struct {
 // the spreadsheets files.
 type xlsxIndex struct {
 	sharedStr string
+	styles    string
 	files     []string
+	sheetRefs []sheetRef
+}
+
+// sheetRef pairs a sheet's declared name with the zip part that holds its
+// data, resolved through workbook.xml.rels rather than guessed from the
+// part's filename.
+type sheetRef struct {
+	name   string
+	target string
 }
 
 // Close closes all the buffers and readers.
@@ -57,6 +111,36 @@ func (xlsx *XLSX) SharedStrings() []string {
 	return xlsx.sharedStrings
 }
 
+// DefinedNames returns the workbook's named ranges, as declared in
+// xl/workbook.xml's <definedNames> block.
+func (xlsx *XLSX) DefinedNames() []DefinedName {
+	return xlsx.definedNames
+}
+
+// Styles parses and returns the workbook's style table.
+//
+// Parsing is lazy and happens at most once, guarded by a sync.Once so
+// concurrent callers (e.g. from SheetsParallel) can't race on the cache.
+func (xlsx *XLSX) Styles() (*Styles, error) {
+	xlsx.stylesOnce.Do(func() {
+		if xlsx.stylesFile == nil {
+			xlsx.styles = &Styles{}
+			return
+		}
+
+		zfr, err := xlsx.stylesFile.Open()
+		if err != nil {
+			xlsx.stylesErr = err
+			return
+		}
+		defer zfr.Close()
+
+		xlsx.styles, xlsx.stylesErr = parseStyles(zfr)
+	})
+
+	return xlsx.styles, xlsx.stylesErr
+}
+
 // Open just opens the file for reading.
 func Open(filename string) (*XLSX, error) {
 	file, err := os.Open(filename)
@@ -72,28 +156,56 @@ func Open(filename string) (*XLSX, error) {
 	return OpenReader(file, st.Size())
 }
 
+// OpenBinary opens an XLSX already held in memory, e.g. an HTTP upload or
+// an S3 GetObject body, without writing it to disk first.
+func OpenBinary(b []byte) (*XLSX, error) {
+	return OpenReader(bytes.NewReader(b), int64(len(b)))
+}
+
 // OpenReader opens the reader as XLSX file.
 func OpenReader(r io.ReaderAt, size int64) (*XLSX, error) {
+	return OpenReaderWithOptions(r, size)
+}
+
+// OpenReaderWithOptions is OpenReader with the behavior of Options applied:
+// WithRowLimit caps how many data rows each sheet yields, WithSheets skips
+// extracting sheets outside the given allow-list, and
+// WithSharedStringsLimit bounds how many shared strings are parsed, which
+// is useful when reading untrusted files.
+func OpenReaderWithOptions(r io.ReaderAt, size int64, opts ...Option) (*XLSX, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	zr, err := zip.NewReader(r, size)
 	if err != nil {
 		return nil, err
 	}
 
 	var (
-		xlsx  *XLSX
-		index xlsxIndex
+		xlsx     *XLSX
+		index    xlsxIndex
+		date1904 bool
+		entries  []sheetEntry
+		rawNames []rawDefinedName
+		rels     map[string]string
 	)
 
-	sheetsName := make(map[string]string)
-
 	for _, zFile := range zr.File {
 		switch zFile.Name {
 		case "xl/workbook.xml":
-			sheetsName, err = parseWorkbook(zFile)
+			entries, rawNames, date1904, err = parseWorkbook(zFile)
 			if err != nil {
 				return nil, fmt.Errorf("parseWorkbook: %s", err)
 			}
 
+		case "xl/_rels/workbook.xml.rels":
+			rels, err = parseWorkbookRels(zFile)
+			if err != nil {
+				return nil, fmt.Errorf("parseWorkbookRels: %s", err)
+			}
+
 		case "[Content_Types].xml":
 			// read where the worksheets are
 			index, err = parseContentType(zFile)
@@ -103,9 +215,23 @@ func OpenReader(r io.ReaderAt, size int64) (*XLSX, error) {
 		}
 	}
 
+	index.sheetRefs = resolveSheetRefs(entries, rels, index.files)
+	filtered := o.sheets != nil
+	if filtered {
+		index.sheetRefs = filterSheetRefs(index.sheetRefs, o.sheets)
+	}
+
 	// read the worksheets
-	xlsx, err = extractWorksheets(zr, &index, sheetsName)
+	xlsx, err = extractWorksheets(zr, &index, o.sharedStringsLimit, filtered)
 	if err == nil {
+		xlsx.date1904 = date1904
+		xlsx.rowLimit = o.rowLimit
+		xlsx.definedNames = resolveDefinedNames(rawNames, entries)
+
+		if len(index.styles) > 0 {
+			xlsx.stylesFile, _ = getZipFile(zr, index.styles)
+		}
+
 		closer, ok := r.(io.Closer)
 		if ok {
 			xlsx.closer = closer
@@ -115,8 +241,35 @@ func OpenReader(r io.ReaderAt, size int64) (*XLSX, error) {
 	return xlsx, err
 }
 
-func parseWorkbook(zFile *zip.File) (sheets map[string]string, err error) {
-	sheets = make(map[string]string)
+// filterSheetRefs drops sheet refs whose name isn't in the allow-list,
+// preserving the original order.
+func filterSheetRefs(refs []sheetRef, allow map[string]bool) []sheetRef {
+	filtered := make([]sheetRef, 0, len(refs))
+	for _, ref := range refs {
+		if allow[ref.name] {
+			filtered = append(filtered, ref)
+		}
+	}
+	return filtered
+}
+
+// sheetEntry is a <sheet> element from xl/workbook.xml, in document order.
+type sheetEntry struct {
+	id   string
+	name string
+	rID  string
+}
+
+// rawDefinedName is a <definedName> element from xl/workbook.xml before its
+// localSheetId has been resolved to a sheet name and its text body has
+// been parsed into a Range.
+type rawDefinedName struct {
+	name         string
+	localSheetID string
+	ref          string
+}
+
+func parseWorkbook(zFile *zip.File) (sheets []sheetEntry, names []rawDefinedName, date1904 bool, err error) {
 	var zfr io.ReadCloser
 
 	zfr, err = zFile.Open()
@@ -125,31 +278,109 @@ func parseWorkbook(zFile *zip.File) (sheets map[string]string, err error) {
 	}
 	defer zfr.Close()
 
+	var (
+		inDefinedName bool
+		cur           rawDefinedName
+		ref           strings.Builder
+	)
+
 	r := xml.NewReader(zfr)
 	for err == nil && r.Next() {
 		switch e := r.Element().(type) {
 		case *xml.StartElement:
-			if !bytes.Equal(e.NameBytes(), sheetString) {
-				continue
-			}
+			switch {
+			case bytes.Equal(e.NameBytes(), workbookPrString):
+				kv := e.Attrs().GetBytes(date1904String)
+				if kv != nil {
+					v := kv.Value()
+					date1904 = v == "1" || v == "true"
+				}
+			case bytes.Equal(e.NameBytes(), sheetString):
+				var entry sheetEntry
+
+				kv := e.Attrs().GetBytes(sheetIDString)
+				if kv == nil {
+					err = errors.New("sheetId parameter not found")
+				} else {
+					entry.id = kv.Value()
+				}
+
+				kv = e.Attrs().GetBytes(sheetNameString)
+				if kv == nil {
+					err = errors.New("name parameter not found")
+				} else {
+					entry.name = kv.Value()
+				}
 
-			var sheetID string
-			kv := e.Attrs().GetBytes(sheetIDString)
-			if kv == nil {
-				err = errors.New("sheetId parameter not found")
-			} else {
-				sheetID = kv.Value()
+				kv = e.Attrs().GetBytes(rIDString)
+				if kv != nil {
+					entry.rID = kv.Value()
+				}
+
+				sheets = append(sheets, entry)
+			case bytes.Equal(e.NameBytes(), definedNameString):
+				inDefinedName = true
+				cur = rawDefinedName{}
+				ref.Reset()
+
+				if kv := e.Attrs().GetBytes(nameString); kv != nil {
+					cur.name = kv.Value()
+				}
+				if kv := e.Attrs().GetBytes(localSheetIDString); kv != nil {
+					cur.localSheetID = kv.Value()
+				}
+			}
+		case *xml.TextElement:
+			if inDefinedName {
+				ref.WriteString(string(*e))
 			}
+		case *xml.EndElement:
+			if bytes.Equal(e.NameBytes(), definedNameString) && inDefinedName {
+				cur.ref = ref.String()
+				names = append(names, cur)
+				inDefinedName = false
+			}
+		}
+	}
+	if err == nil {
+		if r.Error() != nil && r.Error() != io.EOF {
+			err = r.Error()
+		}
+	}
+
+	return
+}
+
+// parseWorkbookRels reads xl/_rels/workbook.xml.rels and builds a map of
+// relationship id to target part path.
+func parseWorkbookRels(zFile *zip.File) (map[string]string, error) {
+	var err error
+	rels := make(map[string]string)
 
-			var name string
-			kv = e.Attrs().GetBytes(sheetNameString)
-			if kv == nil {
-				err = errors.New("name parameter not found")
-			} else {
-				name = kv.Value()
+	zfr, err := zFile.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer zfr.Close()
+
+	r := xml.NewReader(zfr)
+	for err == nil && r.Next() {
+		switch e := r.Element().(type) {
+		case *xml.StartElement:
+			if !bytes.Equal(e.NameBytes(), relationshipString) {
+				continue
 			}
 
-			sheets[sheetID] = name
+			var id, target string
+			if kv := e.Attrs().GetBytes(idString); kv != nil {
+				id = kv.Value()
+			}
+			if kv := e.Attrs().GetBytes(targetString); kv != nil {
+				target = kv.Value()
+			}
+			if id != "" && target != "" {
+				rels[id] = target
+			}
 		}
 	}
 	if err == nil {
@@ -158,9 +389,58 @@ func parseWorkbook(zFile *zip.File) (sheets map[string]string, err error) {
 		}
 	}
 
-	return
+	return rels, err
+}
+
+// resolveSheetRefs joins the sheets declared in xl/workbook.xml with their
+// actual zip part, resolved through workbook.xml.rels by relationship id.
+// When rels are missing or a sheet's relationship can't be resolved, it
+// falls back to positional pairing against the worksheet parts discovered
+// via [Content_Types].xml, preserving the previous best-effort behavior.
+func resolveSheetRefs(entries []sheetEntry, rels map[string]string, files []string) []sheetRef {
+	refs := make([]sheetRef, 0, len(entries))
+
+	for i, entry := range entries {
+		target := rels[entry.rID]
+		if target == "" && i < len(files) {
+			target = files[i]
+		}
+		if target == "" {
+			continue
+		}
+
+		refs = append(refs, sheetRef{name: entry.name, target: resolvePartPath(target)})
+	}
+
+	return refs
 }
 
+// resolvePartPath turns a relationship Target into a package-absolute part
+// path. A leading slash is dropped, and a target that does not then start
+// with "xl/" is taken as relative to the xl/ folder.
+func resolvePartPath(target string) string {
+	target = strings.TrimPrefix(target, "/")
+	if strings.HasPrefix(target, "xl/") {
+		return target
+	}
+	return "xl/" + target
+}
+
+// Element/attribute names used while parsing xl/workbook.xml's <sheets>
+// and [Content_Types].xml's <Override> entries.
+var (
+	sheetString     = []byte("sheet")
+	sheetIDString   = []byte("sheetId")
+	sheetNameString = []byte("name")
+
+	partNameString     = []byte("PartName")
+	overrideString     = []byte("Override")
+	contentTypeString  = []byte("ContentType")
+	workSheetURIString = []byte("application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml")
+
+	sharedStringsURIString = []byte("application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml")
+)
+
 func getPartName(e *xml.StartElement) (partName string, err error) {
 	kv := e.Attrs().GetBytes(partNameString)
 	if kv != nil {
@@ -203,6 +483,11 @@ func parseContentType(zFile *zip.File) (index xlsxIndex, err error) {
 					if err == nil {
 						index.sharedStr = partName
 					}
+				case bytes.Equal(kv.ValueBytes(), stylesURIString):
+					partName, err = getPartName(e)
+					if err == nil {
+						index.styles = partName
+					}
 				}
 			}
 		}
@@ -218,110 +503,156 @@ func parseContentType(zFile *zip.File) (index xlsxIndex, err error) {
 	return
 }
 
-func extractWorksheets(zr *zip.Reader, index *xlsxIndex, sheetsName map[string]string) (*XLSX, error) {
+func extractWorksheets(zr *zip.Reader, index *xlsxIndex, sharedStringsLimit int, sheetsFiltered bool) (*XLSX, error) {
 	var (
-		err    error
-		shared []string
+		err        error
+		shared     []string
+		sharedFile *zip.File
 	)
-	sharedFile := index.sharedStr
 
-	if len(sharedFile) > 0 {
-		shared, err = readShared(zr, sharedFile)
+	sheetFiles := make([]*zip.File, len(index.sheetRefs))
+	for i, ref := range index.sheetRefs {
+		zFile, err := getZipFile(zr, ref.target)
 		if err != nil {
-			return nil, fmt.Errorf("error reading shared strings: %s", err)
+			return nil, err
 		}
+		sheetFiles[i] = zFile
 	}
 
-	xs := new(XLSX)
-	xs.sharedStrings = shared
-
-	for _, filename := range index.files {
-		zFile, err := getZipFile(zr, filename)
+	needShared := len(index.sharedStr) > 0
+	if needShared && sheetsFiltered {
+		needShared = anySheetReferencesSharedStrings(sheetFiles)
+	}
+	if needShared {
+		shared, err = readShared(zr, index.sharedStr, sharedStringsLimit)
 		if err != nil {
-			xs = nil
-			return nil, err
+			return nil, fmt.Errorf("error reading shared strings: %s", err)
 		}
+		sharedFile, _ = getZipFile(zr, index.sharedStr)
+	}
 
-		sheetIDStart := strings.LastIndex(filename, "sheet")
-		sheetIDEnd := strings.LastIndexByte(filename, '.')
-		sheetID := filename[sheetIDStart+5 : sheetIDEnd]
+	xs := new(XLSX)
+	xs.sharedStrings = shared
+	xs.sharedStringsFile = sharedFile
 
+	for i, ref := range index.sheetRefs {
 		xs.sheets = append(xs.sheets, &Sheet{
-			Name:   sheetsName[sheetID],
+			Name:   ref.name,
 			parent: xs,
-			zFile:  zFile,
+			zFile:  sheetFiles[i],
 		})
 	}
 
 	return xs, err
 }
 
-func findNameIn(name, where string) bool {
-	if name[0] == '/' {
-		return name[1:] == where
+// anySheetReferencesSharedStrings reports whether any of the given
+// worksheet parts holds a shared-string cell (t="s"), so extractWorksheets
+// can skip parsing sharedStrings.xml entirely when WithSheets has
+// filtered down to sheets that only ever hold inline or numeric values.
+func anySheetReferencesSharedStrings(files []*zip.File) bool {
+	for _, zFile := range files {
+		if sheetReferencesSharedStrings(zFile) {
+			return true
+		}
 	}
-	return strings.Contains(where, name)
+	return false
 }
 
-func getZipFile(zr *zip.Reader, filename string) (zFile *zip.File, err error) {
-	var found = false
-	for _, zFile = range zr.File {
-		found = findNameIn(filename, zFile.Name)
-		if found {
-			break
-		}
+func sheetReferencesSharedStrings(zFile *zip.File) bool {
+	zfr, err := zFile.Open()
+	if err != nil {
+		return false
 	}
-	if !found {
-		err = fmt.Errorf("%s not found", filename)
+	defer zfr.Close()
+
+	data, err := io.ReadAll(zfr)
+	if err != nil {
+		return false
 	}
 
-	return zFile, err
+	return bytes.Contains(data, []byte(`t="s"`)) || bytes.Contains(data, []byte(`t='s'`))
 }
 
-func readShared(zr *zip.Reader, filename string) ([]string, error) {
-	var (
-		rc    io.ReadCloser
-		found bool
-		err   error
-	)
+// normalizePartPath makes a zip part path comparable across producers that
+// disagree on a leading slash or on path casing.
+func normalizePartPath(name string) string {
+	return strings.ToLower(strings.TrimPrefix(name, "/"))
+}
+
+// getZipFile resolves filename to its *zip.File by exact path match,
+// normalizing the leading "/" and case that different producers use
+// inconsistently.
+func getZipFile(zr *zip.Reader, filename string) (*zip.File, error) {
+	want := normalizePartPath(filename)
 	for _, zFile := range zr.File {
-		found = findNameIn(filename, zFile.Name)
-		if found {
-			rc, err = zFile.Open()
-			break
+		if normalizePartPath(zFile.Name) == want {
+			return zFile, nil
 		}
 	}
-	if !found {
-		err = fmt.Errorf("%s not found", filename)
+
+	return nil, fmt.Errorf("%s not found", filename)
+}
+
+func readShared(zr *zip.Reader, filename string, limit int) ([]string, error) {
+	zFile, err := getZipFile(zr, filename)
+	if err != nil {
+		return nil, err
 	}
+
+	rc, err := zFile.Open()
 	if err != nil {
 		return nil, err
 	}
 	defer rc.Close()
 
-	return parseShared(rc)
+	return parseSharedLimit(rc, limit)
 }
 
+// parseShared reads xl/sharedStrings.xml. Each <si> may hold rich-text
+// runs (multiple <r><rPr>...</rPr><t>...</t></r> children) instead of a
+// single <t>; all of its <t> contents are concatenated into one entry.
 func parseShared(rc io.Reader) ([]string, error) {
-	var err error
+	return parseSharedLimit(rc, 0)
+}
+
+// parseSharedLimit is parseShared bounded to at most limit entries, useful
+// for defensive parsing of untrusted files; limit <= 0 means unlimited.
+func parseSharedLimit(rc io.Reader, limit int) ([]string, error) {
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	spans := scanTextSpans(data)
+
 	ss := make([]string, 0)
-	r := xml.NewReader(rc)
-	T := false
+	r := xml.NewReader(bytes.NewReader(data))
+
+	var (
+		si      strings.Builder
+		spanIdx int
+	)
 loop:
 	for r.Next() {
 		switch e := r.Element().(type) {
 		case *xml.StartElement:
-			T = bytes.Equal(e.NameBytes(), tString)
-			if T && e.HasEnd() {
-				// shared strings sometimes contains empty strings. Don't know why
-				ss = append(ss, "")
-			}
-		case *xml.TextElement:
-			if T {
-				ss = append(ss, string(*e))
+			switch {
+			case bytes.Equal(e.NameBytes(), siString):
+				si.Reset()
+			case bytes.Equal(e.NameBytes(), tString):
+				if spanIdx < len(spans) {
+					si.WriteString(normalizeSpace(spans[spanIdx].text, spans[spanIdx].preserve))
+					spanIdx++
+				}
 			}
 		case *xml.EndElement:
-			if bytes.Equal(e.NameBytes(), sstString) {
+			switch {
+			case bytes.Equal(e.NameBytes(), siString):
+				ss = append(ss, si.String())
+				if limit > 0 && len(ss) >= limit {
+					break loop
+				}
+			case bytes.Equal(e.NameBytes(), sstString):
 				break loop
 			}
 		}
@@ -329,3 +660,248 @@ loop:
 
 	return ss, err
 }
+
+// tSpan is a single <t>...</t> occurrence found by scanTextSpans: its
+// unescaped text and whether it carried xml:space="preserve".
+type tSpan struct {
+	text     string
+	preserve bool
+}
+
+// scanTextSpans finds every <t>...</t> occurrence in raw XML bytes, in
+// document order, and extracts its unescaped text directly from the raw
+// span. quickxml's Reader skips leading whitespace before it has even
+// decided whether the next token is a tag or text, which silently drops
+// meaningful leading space on an xml:space="preserve" run before a
+// TextElement callback ever sees it; scanning the raw bytes ourselves
+// sidesteps that entirely instead of trusting the streamed text.
+func scanTextSpans(data []byte) []tSpan {
+	var spans []tSpan
+
+	for i := 0; i < len(data); {
+		start := bytes.Index(data[i:], []byte("<t"))
+		if start < 0 {
+			break
+		}
+		start += i
+
+		after := start + 2
+		if after >= len(data) || !isTagBoundary(data[after]) {
+			i = start + 2
+			continue
+		}
+
+		tagEnd := bytes.IndexByte(data[after:], '>')
+		if tagEnd < 0 {
+			break
+		}
+		tagEnd += after
+
+		tag := data[start+1 : tagEnd]
+		selfClosed := bytes.HasSuffix(bytes.TrimSpace(tag), []byte("/"))
+		preserve := bytes.Contains(tag, []byte(`xml:space="preserve"`)) ||
+			bytes.Contains(tag, []byte(`xml:space='preserve'`))
+
+		var text string
+		next := tagEnd + 1
+		if !selfClosed {
+			closeIdx := bytes.Index(data[next:], []byte("</t>"))
+			if closeIdx < 0 {
+				break
+			}
+			text = unescapeXMLText(string(data[next : next+closeIdx]))
+			next += closeIdx + len("</t>")
+		}
+
+		spans = append(spans, tSpan{text: text, preserve: preserve})
+		i = next
+	}
+
+	return spans
+}
+
+// isTagBoundary reports whether b can follow an element name, i.e. this
+// "<t" starts a <t> tag rather than some other element like <title>.
+func isTagBoundary(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '>' || b == '/'
+}
+
+// unescapeXMLText decodes the five predefined XML entities plus numeric
+// character references (&#NN; / &#xHH;) in text extracted directly from
+// raw document bytes, bypassing the XML parser entirely.
+func unescapeXMLText(s string) string {
+	if !strings.ContainsRune(s, '&') {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for i := 0; i < len(s); {
+		if s[i] != '&' {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		end := strings.IndexByte(s[i:], ';')
+		if end < 0 {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		entity := s[i+1 : i+end]
+		i += end + 1
+
+		switch {
+		case entity == "amp":
+			b.WriteByte('&')
+		case entity == "lt":
+			b.WriteByte('<')
+		case entity == "gt":
+			b.WriteByte('>')
+		case entity == "quot":
+			b.WriteByte('"')
+		case entity == "apos":
+			b.WriteByte('\'')
+		case strings.HasPrefix(entity, "#x") || strings.HasPrefix(entity, "#X"):
+			if n, err := strconv.ParseInt(entity[2:], 16, 32); err == nil {
+				b.WriteRune(rune(n))
+			}
+		case strings.HasPrefix(entity, "#"):
+			if n, err := strconv.ParseInt(entity[1:], 10, 32); err == nil {
+				b.WriteRune(rune(n))
+			}
+		default:
+			b.WriteByte('&')
+			b.WriteString(entity)
+			b.WriteByte(';')
+		}
+	}
+
+	return b.String()
+}
+
+// normalizeSpace trims a <t> element's text unless it requested
+// xml:space="preserve".
+func normalizeSpace(text string, preserve bool) string {
+	if preserve {
+		return text
+	}
+	return strings.TrimSpace(text)
+}
+
+// Run is a single rich-text run from a shared string's <si>: a fragment of
+// text plus the subset of its <rPr> formatting we understand.
+type Run struct {
+	Text      string
+	Bold      bool
+	Italic    bool
+	Underline bool
+	Color     string
+	Font      string
+	Size      float64
+}
+
+// SharedRuns parses xl/sharedStrings.xml again, this time keeping each
+// <si>'s individual runs and their formatting instead of just the
+// concatenated text. It is parsed lazily and cached (guarded by a
+// sync.Once, like Styles): callers who only need SharedStrings() never
+// pay for this, and concurrent callers can't race on the cache.
+func (xlsx *XLSX) SharedRuns() ([][]Run, error) {
+	xlsx.sharedRunsOnce.Do(func() {
+		if xlsx.sharedStringsFile == nil {
+			return
+		}
+
+		zfr, err := xlsx.sharedStringsFile.Open()
+		if err != nil {
+			xlsx.sharedRunsErr = err
+			return
+		}
+		defer zfr.Close()
+
+		xlsx.sharedRuns, xlsx.sharedRunsErr = parseSharedRuns(zfr)
+	})
+
+	return xlsx.sharedRuns, xlsx.sharedRunsErr
+}
+
+// parseSharedRuns is the rich-run counterpart of parseShared: it keeps each
+// run's text and its <rPr> formatting instead of collapsing an <si> down to
+// one plain string.
+func parseSharedRuns(rc io.Reader) ([][]Run, error) {
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	spans := scanTextSpans(data)
+
+	runs := make([][]Run, 0)
+	r := xml.NewReader(bytes.NewReader(data))
+
+	var (
+		siRuns  []Run
+		cur     Run
+		inRun   bool
+		inRPr   bool
+		spanIdx int
+	)
+loop:
+	for r.Next() {
+		switch e := r.Element().(type) {
+		case *xml.StartElement:
+			switch {
+			case bytes.Equal(e.NameBytes(), siString):
+				siRuns = nil
+			case bytes.Equal(e.NameBytes(), rString):
+				cur = Run{}
+				inRun = true
+			case bytes.Equal(e.NameBytes(), rPrString):
+				inRPr = true
+			case inRPr && bytes.Equal(e.NameBytes(), boldString):
+				cur.Bold = true
+			case inRPr && bytes.Equal(e.NameBytes(), italicString):
+				cur.Italic = true
+			case inRPr && bytes.Equal(e.NameBytes(), underlineString):
+				cur.Underline = true
+			case inRPr && bytes.Equal(e.NameBytes(), colorString):
+				if kv := e.Attrs().GetBytes(rgbString); kv != nil {
+					cur.Color = kv.Value()
+				}
+			case inRPr && bytes.Equal(e.NameBytes(), rFontString):
+				if kv := e.Attrs().GetBytes(valString); kv != nil {
+					cur.Font = kv.Value()
+				}
+			case inRPr && bytes.Equal(e.NameBytes(), sizeString):
+				if kv := e.Attrs().GetBytes(valString); kv != nil {
+					cur.Size, _ = strconv.ParseFloat(kv.Value(), 64)
+				}
+			case bytes.Equal(e.NameBytes(), tString):
+				if spanIdx < len(spans) {
+					text := normalizeSpace(spans[spanIdx].text, spans[spanIdx].preserve)
+					spanIdx++
+					if inRun {
+						cur.Text = text
+					} else {
+						siRuns = append(siRuns, Run{Text: text})
+					}
+				}
+			}
+		case *xml.EndElement:
+			switch {
+			case bytes.Equal(e.NameBytes(), rPrString):
+				inRPr = false
+			case bytes.Equal(e.NameBytes(), rString):
+				siRuns = append(siRuns, cur)
+				inRun = false
+			case bytes.Equal(e.NameBytes(), siString):
+				runs = append(runs, siRuns)
+			case bytes.Equal(e.NameBytes(), sstString):
+				break loop
+			}
+		}
+	}
+
+	return runs, err
+}
@@ -44,6 +44,48 @@ func TestParseSharedWithEmpty(t *testing.T) {
 	}
 }
 
+func TestParseSharedRichText(t *testing.T) {
+	const sharedStringsText = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="1" uniqueCount="1"><si><r><rPr><b/></rPr><t xml:space="preserve">Hello </t></r><r><rPr><i/><color rgb="FFFF0000"/></rPr><t>World</t></r></si></sst>`
+	r := strings.NewReader(sharedStringsText)
+	ss, err := parseShared(r)
+	if err != nil {
+		t.Fatalf("Unexpected err: %q", err)
+	}
+	if len(ss) != 1 || ss[0] != "Hello World" {
+		t.Fatalf("Unexpected concatenated string: %q", ss)
+	}
+}
+
+func TestSharedRuns(t *testing.T) {
+	const sharedStringsText = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="1" uniqueCount="1"><si><r><rPr><b/></rPr><t xml:space="preserve">Hello </t></r><r><rPr><i/><color rgb="FFFF0000"/></rPr><t>World</t></r></si></sst>`
+	runs, err := parseSharedRuns(strings.NewReader(sharedStringsText))
+	if err != nil {
+		t.Fatalf("Unexpected err: %q", err)
+	}
+
+	if len(runs) != 1 || len(runs[0]) != 2 {
+		t.Fatalf("Unexpected runs: %+v", runs)
+	}
+
+	if !runs[0][0].Bold || runs[0][0].Text != "Hello " {
+		t.Fatalf("Unexpected first run: %+v", runs[0][0])
+	}
+	if !runs[0][1].Italic || runs[0][1].Color != "FFFF0000" || runs[0][1].Text != "World" {
+		t.Fatalf("Unexpected second run: %+v", runs[0][1])
+	}
+}
+
+func TestParseSharedSpacePreserve(t *testing.T) {
+	const sharedStringsText = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="1" uniqueCount="1"><si><t xml:space="preserve">  padded  </t></si></sst>`
+	ss, err := parseShared(strings.NewReader(sharedStringsText))
+	if err != nil {
+		t.Fatalf("Unexpected err: %q", err)
+	}
+	if len(ss) != 1 || ss[0] != "  padded  " {
+		t.Fatalf("Unexpected preserved string: %q", ss)
+	}
+}
+
 const xlsxFile = "test/spreadsheet.xlsx"
 
 func TestParseContentType(t *testing.T) {
@@ -129,7 +171,7 @@ func TestReadShared(t *testing.T) {
 				t.Fatalf("Unexpected sharedStrings file: %s", index.sharedStr)
 			}
 
-			shared, err := readShared(zr, index.sharedStr)
+			shared, err := readShared(zr, index.sharedStr, 0)
 			if err != nil {
 				t.Fatal(err)
 			}
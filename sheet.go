@@ -0,0 +1,265 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strconv"
+
+	xml "github.com/dgrr/quickxml"
+)
+
+// Element/attribute names used while parsing a worksheet's <sheetData>.
+var (
+	sheetDataString = []byte("sheetData")
+	rowElemString   = []byte("row")
+	cString         = []byte("c")
+	vString         = []byte("v")
+	isString        = []byte("is")
+	sAttrString     = []byte("s")
+	rAttrString     = []byte("r")
+)
+
+// tString and sstString are also read by the shared-strings parser in
+// reader.go: a worksheet's inline <is><t> and sharedStrings.xml's <sst>/<t>
+// happen to reuse the same element names.
+var (
+	tString   = []byte("t")
+	sstString = []byte("sst")
+)
+
+// Sheet is a single worksheet: its declared name plus the zip part that
+// holds its <sheetData>, resolved by extractWorksheets.
+type Sheet struct {
+	Name   string
+	parent *XLSX
+	zFile  *zip.File
+}
+
+// Open starts streaming the sheet's rows on demand. Row returns the
+// current row's raw cell values (shared-string and inline-string values
+// already resolved), and Cells returns the same row classified through
+// the workbook's style table (see Styles, Cell).
+func (s *Sheet) Open() (RowIter, error) {
+	zfr, err := s.zFile.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return &sheetRowIter{sheet: s, zfr: zfr, r: xml.NewReader(zfr)}, nil
+}
+
+// RowIter streams a worksheet's rows one at a time; like bufio.Scanner,
+// Row (and Cells) describe the row most recently returned by Next.
+type RowIter interface {
+	// Next advances to the next row. It reports false at the end of
+	// sheetData, once a WithRowLimit cap has been reached, or on error.
+	Next() bool
+	// Row returns the current row's raw cell values.
+	Row() []string
+	// Cells returns the current row's cells, classified using the
+	// workbook's style table.
+	Cells() []Cell
+	// RowNumber returns the 0-based row number (the <row r="..."> index)
+	// of the row most recently returned by Next. Producers commonly omit
+	// <row> elements for blank rows, so this can jump ahead of the count
+	// of Next calls made so far.
+	RowNumber() int
+	// Error returns the first error encountered while iterating, if any.
+	Error() error
+	// Close releases the underlying zip entry reader.
+	Close() error
+}
+
+// sheetRowIter is the concrete RowIter returned by Sheet.Open.
+type sheetRowIter struct {
+	sheet *Sheet
+	zfr   io.ReadCloser
+	r     *xml.Reader
+
+	row   []string
+	cells []Cell
+
+	rowNum     int
+	nextRowNum int
+	rowsRead   int
+	err        error
+	done       bool
+}
+
+// Next advances to the next row. Once the workbook's WithRowLimit option
+// has yielded that many rows for this sheet, it returns false without
+// reading any further, just like reaching the real end of sheetData.
+func (it *sheetRowIter) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	if limit := it.sheet.parent.rowLimit; limit > 0 && it.rowsRead >= limit {
+		it.done = true
+		return false
+	}
+
+	for it.r.Next() {
+		e, ok := it.r.Element().(*xml.StartElement)
+		if !ok || !bytes.Equal(e.NameBytes(), rowElemString) {
+			continue
+		}
+
+		rowNum := it.nextRowNum
+		if kv := e.Attrs().GetBytes(rAttrString); kv != nil {
+			if n, err := strconv.Atoi(kv.Value()); err == nil && n > 0 {
+				rowNum = n - 1
+			}
+		}
+
+		row, cells, err := it.readRow()
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.row, it.cells = row, cells
+		it.rowNum = rowNum
+		it.nextRowNum = rowNum + 1
+		it.rowsRead++
+		return true
+	}
+
+	if it.r.Error() != nil && it.r.Error() != io.EOF {
+		it.err = it.r.Error()
+	}
+	it.done = true
+	return false
+}
+
+// readRow consumes a single <row>...</row>, decoding each <c> into both
+// its raw display value and a Cell classified via classifyCell. Cells are
+// placed at the column index encoded in their own `r=` reference (e.g.
+// "C7" is column 2), not at their position in the XML, since producers
+// commonly omit <c> elements for blank cells.
+func (it *sheetRowIter) readRow() ([]string, []Cell, error) {
+	var (
+		row   []string
+		cells []Cell
+
+		inCell    bool
+		styleAttr string
+		typeAttr  string
+		colIdx    int
+		nextIdx   int
+		inVal     bool
+		inInlineT bool
+		val       bytes.Buffer
+	)
+
+	for it.r.Next() {
+		switch e := it.r.Element().(type) {
+		case *xml.StartElement:
+			switch {
+			case bytes.Equal(e.NameBytes(), cString):
+				inCell = true
+				styleAttr, typeAttr = "", ""
+				val.Reset()
+				colIdx = nextIdx
+				if kv := e.Attrs().GetBytes(rAttrString); kv != nil {
+					if idx := columnIndexFromCellRef(kv.Value()); idx >= 0 {
+						colIdx = idx
+					}
+				}
+				if kv := e.Attrs().GetBytes(sAttrString); kv != nil {
+					styleAttr = kv.Value()
+				}
+				if kv := e.Attrs().GetBytes(tString); kv != nil {
+					typeAttr = kv.Value()
+				}
+			case inCell && bytes.Equal(e.NameBytes(), vString):
+				inVal = true
+			case inCell && bytes.Equal(e.NameBytes(), tString):
+				inInlineT = true
+			}
+		case *xml.TextElement:
+			if inVal || inInlineT {
+				val.WriteString(string(*e))
+			}
+		case *xml.EndElement:
+			switch {
+			case bytes.Equal(e.NameBytes(), vString):
+				inVal = false
+			case bytes.Equal(e.NameBytes(), tString):
+				inInlineT = false
+			case bytes.Equal(e.NameBytes(), cString):
+				raw := val.String()
+
+				cellType := typeAttr
+				if typeAttr == "s" {
+					if idx, err := strconv.Atoi(raw); err == nil {
+						if shared := it.sheet.parent.sharedStrings; idx >= 0 && idx < len(shared) {
+							raw = shared[idx]
+						}
+					}
+					cellType = "str"
+				}
+
+				for len(row) <= colIdx {
+					row = append(row, "")
+					cells = append(cells, Cell{})
+				}
+				row[colIdx] = raw
+				cells[colIdx] = classifyCell(it.sheet.parent, raw, styleAttr, cellType)
+
+				nextIdx = colIdx + 1
+				inCell = false
+			case bytes.Equal(e.NameBytes(), rowElemString):
+				return row, cells, nil
+			case bytes.Equal(e.NameBytes(), sheetDataString):
+				return row, cells, nil
+			}
+		}
+	}
+
+	if it.r.Error() != nil && it.r.Error() != io.EOF {
+		return row, cells, it.r.Error()
+	}
+	return row, cells, nil
+}
+
+// columnIndexFromCellRef extracts the 0-based column index from an
+// A1-style cell reference such as "C7", returning -1 if it doesn't start
+// with a column letter.
+func columnIndexFromCellRef(ref string) int {
+	i := 0
+	for i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z' {
+		i++
+	}
+	if i == 0 {
+		return -1
+	}
+	return columnToIndex(ref[:i])
+}
+
+// Row returns the current row's raw cell values.
+func (it *sheetRowIter) Row() []string {
+	return it.row
+}
+
+// Cells returns the current row's cells, classified using the workbook's
+// style table.
+func (it *sheetRowIter) Cells() []Cell {
+	return it.cells
+}
+
+// RowNumber returns the 0-based row number of the row most recently
+// returned by Next.
+func (it *sheetRowIter) RowNumber() int {
+	return it.rowNum
+}
+
+// Error returns the first error encountered while iterating, if any.
+func (it *sheetRowIter) Error() error {
+	return it.err
+}
+
+// Close releases the underlying zip entry reader.
+func (it *sheetRowIter) Close() error {
+	return it.zfr.Close()
+}
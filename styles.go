@@ -0,0 +1,407 @@
+package xlsx
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	xml "github.com/dgrr/quickxml"
+)
+
+// xl/workbook.xml and xl/styles.xml element/attribute names used while
+// parsing the style table and the Date1904 workbook flag.
+var (
+	workbookPrString = []byte("workbookPr")
+	date1904String   = []byte("date1904")
+
+	stylesURIString = []byte("application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml")
+
+	numFmtsString    = []byte("numFmts")
+	numFmtString     = []byte("numFmt")
+	numFmtIDString   = []byte("numFmtId")
+	formatCodeString = []byte("formatCode")
+
+	cellXfsString = []byte("cellXfs")
+	xfString      = []byte("xf")
+
+	fontsString     = []byte("fonts")
+	fontString      = []byte("font")
+	boldString      = []byte("b")
+	italicString    = []byte("i")
+	underlineString = []byte("u")
+	colorString     = []byte("color")
+	nameString      = []byte("name")
+	sizeString      = []byte("sz")
+	rgbString       = []byte("rgb")
+
+	fillsString       = []byte("fills")
+	fillString        = []byte("fill")
+	patternFillString = []byte("patternFill")
+	patternTypeString = []byte("patternType")
+	fgColorString     = []byte("fgColor")
+	bgColorString     = []byte("bgColor")
+)
+
+// CellType classifies the interpreted value of a Cell, derived from its
+// `t=` attribute and the number format of the style it points at.
+type CellType int
+
+// Cell types recognized while walking sheetData.
+const (
+	CellString CellType = iota
+	CellNumber
+	CellDate
+	CellBool
+	CellError
+)
+
+// builtinNumFmts are the number format ids 0-49 reserved by the OOXML
+// spec (ECMA-376 18.8.30); producers only need to define numFmts for ids
+// 164 and above.
+var builtinNumFmts = map[int]string{
+	0:  "General",
+	1:  "0",
+	2:  "0.00",
+	3:  "#,##0",
+	4:  "#,##0.00",
+	9:  "0%",
+	10: "0.00%",
+	11: "0.00E+00",
+	12: "# ?/?",
+	13: "# ??/??",
+	14: "mm-dd-yy",
+	15: "d-mmm-yy",
+	16: "d-mmm",
+	17: "mmm-yy",
+	18: "h:mm AM/PM",
+	19: "h:mm:ss AM/PM",
+	20: "h:mm",
+	21: "h:mm:ss",
+	22: "m/d/yy h:mm",
+	37: "#,##0 ;(#,##0)",
+	38: "#,##0 ;[Red](#,##0)",
+	39: "#,##0.00;(#,##0.00)",
+	40: "#,##0.00;[Red](#,##0.00)",
+	45: "mm:ss",
+	46: "[h]:mm:ss",
+	47: "mmss.0",
+	48: "##0.0E+0",
+	49: "@",
+}
+
+// dateNumFmts are the builtin ids that represent a date/time, used to
+// classify a numeric cell as CellDate when it carries one of them.
+var dateNumFmts = map[int]bool{
+	14: true, 15: true, 16: true, 17: true, 18: true,
+	19: true, 20: true, 21: true, 22: true, 45: true, 46: true, 47: true,
+}
+
+// Font describes a parsed xl/styles.xml <font> entry.
+type Font struct {
+	Bold      bool
+	Italic    bool
+	Underline bool
+	Name      string
+	Size      float64
+	Color     string
+}
+
+// Fill describes a parsed xl/styles.xml <fill> entry.
+type Fill struct {
+	PatternType string
+	FgColor     string
+	BgColor     string
+}
+
+// CellXf is a cell format record from <cellXfs>: the piece of a `s="..."`
+// style index that matters for value classification is its numFmtId.
+type CellXf struct {
+	NumFmtID int
+}
+
+// Styles is the parsed xl/styles.xml style table.
+type Styles struct {
+	NumFmts map[int]string
+	CellXfs []CellXf
+	Fonts   []Font
+	Fills   []Fill
+}
+
+// NumFmt returns the format code for the given id, falling back to the
+// builtin Excel formats (0-49) when the workbook didn't redefine it.
+func (s *Styles) NumFmt(id int) string {
+	if s != nil {
+		if code, ok := s.NumFmts[id]; ok {
+			return code
+		}
+	}
+	return builtinNumFmts[id]
+}
+
+// numFmtID returns the numFmtId of the cellXf at the given style index,
+// or -1 when the index is out of range (e.g. the cell carries no `s=`
+// attribute).
+func (s *Styles) numFmtID(styleIdx int) int {
+	if s == nil || styleIdx < 0 || styleIdx >= len(s.CellXfs) {
+		return -1
+	}
+	return s.CellXfs[styleIdx].NumFmtID
+}
+
+// isDateNumFmt reports whether a numFmtId renders as a date/time, either
+// because it is one of the builtin date formats or because its format
+// code contains date/time tokens outside of quoted literals.
+func (s *Styles) isDateNumFmt(id int) bool {
+	if dateNumFmts[id] {
+		return true
+	}
+	if id < 164 {
+		return false
+	}
+	return isDateFormatCode(s.NumFmt(id))
+}
+
+// isDateFormatCode is a small heuristic over a custom format code: date
+// formats use y, m, d, h, s tokens outside of quoted sections.
+func isDateFormatCode(code string) bool {
+	inQuote := false
+	for _, r := range code {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case inQuote:
+			continue
+		case r == 'y' || r == 'Y' || r == 'd' || r == 'D' || r == 'h' || r == 'H' || r == 's' || r == 'S':
+			return true
+		case r == 'm' || r == 'M':
+			return true
+		}
+	}
+	return false
+}
+
+// Cell is a single sheetData value, classified using the workbook's style
+// table and the cell's `t=` attribute.
+type Cell struct {
+	Raw      string
+	Type     CellType
+	numFmtID int
+	xlsx     *XLSX
+}
+
+// classifyCell turns a raw cell value plus its `s=` style index and `t=`
+// type attribute into a typed Cell.
+func classifyCell(xlsx *XLSX, raw, styleAttr, typeAttr string) Cell {
+	c := Cell{Raw: raw, xlsx: xlsx, numFmtID: -1}
+
+	styles, _ := xlsx.Styles()
+
+	styleIdx := -1
+	if styleAttr != "" {
+		styleIdx, _ = strconv.Atoi(styleAttr)
+	}
+	c.numFmtID = styles.numFmtID(styleIdx)
+
+	switch typeAttr {
+	case "str", "inlineStr", "s":
+		c.Type = CellString
+	case "b":
+		c.Type = CellBool
+	case "e":
+		c.Type = CellError
+	default:
+		// numeric, possibly a date depending on the cell's style
+		if styles.isDateNumFmt(c.numFmtID) {
+			c.Type = CellDate
+		} else {
+			c.Type = CellNumber
+		}
+	}
+
+	return c
+}
+
+// AsFloat parses the raw numeric value of the cell.
+func (c Cell) AsFloat() (float64, error) {
+	return strconv.ParseFloat(c.Raw, 64)
+}
+
+// excelEpoch1900 is the OOXML serial date epoch for non-1904 workbooks.
+// Excel's serial 60 is the fictitious Feb 29 1900, so the real epoch used
+// for arithmetic is Dec 30 1899.
+var excelEpoch1900 = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+var excelEpoch1904 = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// AsTime converts the cell's numeric serial value to a time.Time, honoring
+// the workbook's Date1904 flag.
+func (c Cell) AsTime() (time.Time, error) {
+	f, err := c.AsFloat()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	epoch := excelEpoch1900
+	if c.xlsx != nil && c.xlsx.date1904 {
+		epoch = excelEpoch1904
+	}
+
+	days := int64(f)
+	frac := f - float64(days)
+	d := time.Duration(frac * float64(24*time.Hour))
+
+	return epoch.AddDate(0, 0, int(days)).Add(d), nil
+}
+
+// Format renders the cell using its number format, falling back to the
+// raw value for non-numeric cells or formats we don't render specially.
+// Date cells are rendered as "2006-01-02 15:04:05" whatever their format
+// code. Other numeric cells are rendered only when the format code is a
+// plain digit pattern such as "0.00" or "#,##0", and then only its number
+// of decimal places is applied (thousands separators are not inserted);
+// any other code, including General, returns the raw value.
+func (c Cell) Format() string {
+	if c.Type != CellNumber && c.Type != CellDate {
+		return c.Raw
+	}
+
+	var styles *Styles
+	if c.xlsx != nil {
+		styles, _ = c.xlsx.Styles()
+	}
+	code := styles.NumFmt(c.numFmtID)
+
+	if c.Type == CellDate {
+		t, err := c.AsTime()
+		if err != nil {
+			return c.Raw
+		}
+		return t.Format("2006-01-02 15:04:05")
+	}
+
+	if code == "" || strings.Trim(code, "0#,.") != "" {
+		return c.Raw
+	}
+
+	f, err := c.AsFloat()
+	if err != nil {
+		return c.Raw
+	}
+
+	decimals := 0
+	if i := strings.IndexByte(code, '.'); i >= 0 {
+		for _, r := range code[i+1:] {
+			if r != '0' {
+				break
+			}
+			decimals++
+		}
+	}
+
+	return strconv.FormatFloat(f, 'f', decimals, 64)
+}
+
+// parseStyles reads xl/styles.xml, following the same quickxml-based
+// pattern as parseShared and parseWorkbook.
+func parseStyles(rc io.Reader) (*Styles, error) {
+	st := &Styles{NumFmts: make(map[int]string)}
+
+	var (
+		err     error
+		section []byte // numFmts, cellXfs, fonts or fills - which list we're inside
+		curFont Font
+		curFill Fill
+	)
+
+	r := xml.NewReader(rc)
+	for err == nil && r.Next() {
+		switch e := r.Element().(type) {
+		case *xml.StartElement:
+			name := e.NameBytes()
+			switch {
+			case bytes.Equal(name, numFmtsString):
+				section = numFmtsString
+			case bytes.Equal(name, cellXfsString):
+				section = cellXfsString
+			case bytes.Equal(name, fontsString):
+				section = fontsString
+			case bytes.Equal(name, fillsString):
+				section = fillsString
+
+			case bytes.Equal(name, numFmtString) && bytes.Equal(section, numFmtsString):
+				id := -1
+				if kv := e.Attrs().GetBytes(numFmtIDString); kv != nil {
+					id, _ = strconv.Atoi(kv.Value())
+				}
+				if kv := e.Attrs().GetBytes(formatCodeString); kv != nil && id >= 0 {
+					st.NumFmts[id] = kv.Value()
+				}
+
+			case bytes.Equal(name, xfString) && bytes.Equal(section, cellXfsString):
+				xf := CellXf{}
+				if kv := e.Attrs().GetBytes(numFmtIDString); kv != nil {
+					xf.NumFmtID, _ = strconv.Atoi(kv.Value())
+				}
+				st.CellXfs = append(st.CellXfs, xf)
+
+			case bytes.Equal(name, fontString) && bytes.Equal(section, fontsString):
+				curFont = Font{}
+			case bytes.Equal(name, boldString) && bytes.Equal(section, fontsString):
+				curFont.Bold = true
+			case bytes.Equal(name, italicString) && bytes.Equal(section, fontsString):
+				curFont.Italic = true
+			case bytes.Equal(name, underlineString) && bytes.Equal(section, fontsString):
+				curFont.Underline = true
+			case bytes.Equal(name, nameString) && bytes.Equal(section, fontsString):
+				if kv := e.Attrs().GetBytes(valString); kv != nil {
+					curFont.Name = kv.Value()
+				}
+			case bytes.Equal(name, sizeString) && bytes.Equal(section, fontsString):
+				if kv := e.Attrs().GetBytes(valString); kv != nil {
+					curFont.Size, _ = strconv.ParseFloat(kv.Value(), 64)
+				}
+			case bytes.Equal(name, colorString) && bytes.Equal(section, fontsString):
+				if kv := e.Attrs().GetBytes(rgbString); kv != nil {
+					curFont.Color = kv.Value()
+				}
+
+			case bytes.Equal(name, fillString) && bytes.Equal(section, fillsString):
+				curFill = Fill{}
+			case bytes.Equal(name, patternFillString) && bytes.Equal(section, fillsString):
+				if kv := e.Attrs().GetBytes(patternTypeString); kv != nil {
+					curFill.PatternType = kv.Value()
+				}
+			case bytes.Equal(name, fgColorString) && bytes.Equal(section, fillsString):
+				if kv := e.Attrs().GetBytes(rgbString); kv != nil {
+					curFill.FgColor = kv.Value()
+				}
+			case bytes.Equal(name, bgColorString) && bytes.Equal(section, fillsString):
+				if kv := e.Attrs().GetBytes(rgbString); kv != nil {
+					curFill.BgColor = kv.Value()
+				}
+			}
+
+		case *xml.EndElement:
+			name := e.NameBytes()
+			switch {
+			case bytes.Equal(name, fontString) && bytes.Equal(section, fontsString):
+				st.Fonts = append(st.Fonts, curFont)
+			case bytes.Equal(name, fillString) && bytes.Equal(section, fillsString):
+				st.Fills = append(st.Fills, curFill)
+			case bytes.Equal(name, numFmtsString), bytes.Equal(name, cellXfsString),
+				bytes.Equal(name, fontsString), bytes.Equal(name, fillsString):
+				section = nil
+			}
+		}
+	}
+	if err == nil {
+		if r.Error() != nil && r.Error() != io.EOF {
+			err = r.Error()
+		}
+	}
+
+	return st, err
+}
+
+var valString = []byte("val")
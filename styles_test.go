@@ -0,0 +1,59 @@
+package xlsx
+
+import (
+	"strings"
+	"testing"
+)
+
+const stylesText = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/></numFmts><fonts count="1"><font><b/><sz val="11"/><color rgb="FF000000"/><name val="Calibri"/></font></fonts><fills count="1"><fill><patternFill patternType="solid"><fgColor rgb="FFFFFF00"/></patternFill></fill></fills><cellXfs count="2"><xf numFmtId="0"/><xf numFmtId="164"/></cellXfs></styleSheet>`
+
+func TestParseStyles(t *testing.T) {
+	st, err := parseStyles(strings.NewReader(stylesText))
+	if err != nil {
+		t.Fatalf("Unexpected err: %q", err)
+	}
+
+	if st.NumFmt(164) != "yyyy-mm-dd" {
+		t.Fatalf("Unexpected custom numFmt: %s", st.NumFmt(164))
+	}
+	if st.NumFmt(0) != "General" {
+		t.Fatalf("Unexpected builtin numFmt: %s", st.NumFmt(0))
+	}
+
+	if len(st.CellXfs) != 2 || st.CellXfs[1].NumFmtID != 164 {
+		t.Fatalf("Unexpected cellXfs: %+v", st.CellXfs)
+	}
+
+	if len(st.Fonts) != 1 || !st.Fonts[0].Bold || st.Fonts[0].Name != "Calibri" {
+		t.Fatalf("Unexpected font: %+v", st.Fonts)
+	}
+
+	if len(st.Fills) != 1 || st.Fills[0].PatternType != "solid" || st.Fills[0].FgColor != "FFFFFF00" {
+		t.Fatalf("Unexpected fill: %+v", st.Fills)
+	}
+}
+
+func TestClassifyCell(t *testing.T) {
+	x := &XLSX{}
+	x.stylesOnce.Do(func() {
+		x.styles = &Styles{CellXfs: []CellXf{{NumFmtID: 0}, {NumFmtID: 14}}}
+	})
+
+	c := classifyCell(x, "43922", "1", "")
+	if c.Type != CellDate {
+		t.Fatalf("Unexpected type: %v, want CellDate", c.Type)
+	}
+
+	tm, err := c.AsTime()
+	if err != nil {
+		t.Fatalf("Unexpected err: %q", err)
+	}
+	if tm.Year() != 2020 {
+		t.Fatalf("Unexpected year: %d", tm.Year())
+	}
+
+	c = classifyCell(x, "hello", "0", "str")
+	if c.Type != CellString {
+		t.Fatalf("Unexpected type: %v, want CellString", c.Type)
+	}
+}
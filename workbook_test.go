@@ -0,0 +1,73 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+const nonCanonicalContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheet_foo.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/worksheet_bar.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+
+const nonCanonicalWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/><sheet name="Sheet2" sheetId="2" r:id="rId2"/></sheets></workbook>`
+
+const nonCanonicalRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheet_foo.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheet_bar.xml"/></Relationships>`
+
+const minimalSheetXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/></worksheet>`
+
+// buildNonCanonicalXLSX builds an in-memory workbook whose worksheet parts
+// are named arbitrarily (as LibreOffice/template-based producers do)
+// instead of the conventional sheetN.xml.
+func buildNonCanonicalXLSX(t *testing.T) *bytes.Reader {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+
+	files := map[string]string{
+		"[Content_Types].xml":        nonCanonicalContentTypes,
+		"xl/workbook.xml":            nonCanonicalWorkbook,
+		"xl/_rels/workbook.xml.rels": nonCanonicalRels,
+		"xl/worksheet_foo.xml":       minimalSheetXML,
+		"xl/worksheet_bar.xml":       minimalSheetXML,
+	}
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestParseWorkbookRelsJoin(t *testing.T) {
+	r := buildNonCanonicalXLSX(t)
+
+	file, err := OpenReader(r, int64(r.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	sheets := file.Sheets()
+	if len(sheets) != 2 {
+		t.Fatalf("Unexpected sheet count: %d", len(sheets))
+	}
+
+	expected := map[string]string{
+		"Sheet1": "xl/worksheet_foo.xml",
+		"Sheet2": "xl/worksheet_bar.xml",
+	}
+	for _, sheet := range sheets {
+		if sheet.zFile.Name != expected[sheet.Name] {
+			t.Fatalf("sheet %s resolved to %s, want %s", sheet.Name, sheet.zFile.Name, expected[sheet.Name])
+		}
+	}
+}